@@ -0,0 +1,42 @@
+package glob
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+)
+
+// Sentinel errors returned by GetIgnores and GetPaths. Callers should use
+// errors.Is to distinguish between them, since they are always wrapped with
+// the offending path for a useful error message.
+var (
+	// ErrDirNotExist is returned when the directory passed to GetIgnores or
+	// GetPaths does not exist on the underlying filesystem.
+	ErrDirNotExist = errors.New("directory does not exist")
+
+	// ErrNotADirectory is returned when the path passed to GetIgnores or
+	// GetPaths exists but is a file rather than a directory.
+	ErrNotADirectory = errors.New("path is not a directory")
+
+	// ErrNoFilesFound is returned by GetPaths when dir contains no files
+	// once ignore rules have been applied.
+	ErrNoFilesFound = errors.New("no files found in directory")
+)
+
+// checkDir validates that dir exists and is a directory on fs, returning a
+// wrapped ErrDirNotExist or ErrNotADirectory otherwise.
+func checkDir(fs afero.Fs, dir string) error {
+	info, err := fs.Stat(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s: %w", dir, ErrDirNotExist)
+		}
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s: %w", dir, ErrNotADirectory)
+	}
+	return nil
+}
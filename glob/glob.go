@@ -0,0 +1,112 @@
+// Package glob provides helpers for enumerating the files that make up a
+// Vercel deployment, applying `.vercelignore` rules along the way.
+package glob
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gobwas/glob"
+	"github.com/spf13/afero"
+)
+
+// DefaultFs is the filesystem used when callers don't provide one of their
+// own. Production code should always end up using this; tests can swap in
+// an in-memory afero.Fs to avoid touching disk.
+var DefaultFs afero.Fs = afero.NewOsFs()
+
+const ignoreFile = ".vercelignore"
+
+// GetIgnores reads the `.vercelignore` file (if present) at the root of dir
+// and returns the glob patterns it contains. A missing ignore file is not an
+// error - it simply means nothing is ignored.
+func GetIgnores(fs afero.Fs, dir string) ([]string, error) {
+	if err := checkDir(fs, dir); err != nil {
+		return nil, err
+	}
+
+	f, err := fs.Open(filepath.Join(dir, ignoreFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var ignores []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ignores = append(ignores, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return ignores, nil
+}
+
+// GetPaths walks dir recursively on fs, returning the path (as joined by the
+// walk, i.e. prefixed with dir) of every file that does not match one of
+// the ignores patterns. Patterns are matched against each file's path
+// relative to dir, but the paths returned are not made relative themselves.
+func GetPaths(fs afero.Fs, dir string, ignores []string) ([]string, error) {
+	if err := checkDir(fs, dir); err != nil {
+		return nil, err
+	}
+
+	compiled := make([]glob.Glob, 0, len(ignores))
+	for _, pattern := range ignores {
+		g, err := glob.Compile(pattern)
+		if err != nil {
+			// Not every line in a .vercelignore file is a valid glob
+			// pattern - skip anything we can't compile rather than
+			// failing the whole read.
+			continue
+		}
+		compiled = append(compiled, g)
+	}
+
+	var paths []string
+	err := afero.Walk(fs, dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if info.Name() == ignoreFile {
+			return nil
+		}
+
+		rel, relErr := filepath.Rel(dir, path)
+		if relErr != nil {
+			rel = path
+		}
+
+		for _, g := range compiled {
+			if g.Match(rel) {
+				return nil
+			}
+		}
+
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("%s: %w", dir, ErrNoFilesFound)
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,81 @@
+package glob
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestGetPaths(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/src/index.js", []byte("console.log('hi')"), 0644)
+	afero.WriteFile(fs, "/src/README.md", []byte("# readme"), 0644)
+	afero.WriteFile(fs, "/src/node_modules/dep/index.js", []byte("module.exports = {}"), 0644)
+	afero.WriteFile(fs, "/src/.vercelignore", []byte("node_modules/**\n*.md\n"), 0644)
+
+	ignores, err := GetIgnores(fs, "/src")
+	if err != nil {
+		t.Fatalf("GetIgnores returned unexpected error: %s", err)
+	}
+
+	paths, err := GetPaths(fs, "/src", ignores)
+	if err != nil {
+		t.Fatalf("GetPaths returned unexpected error: %s", err)
+	}
+
+	sort.Strings(paths)
+	want := []string{"/src/index.js"}
+	if len(paths) != len(want) || paths[0] != want[0] {
+		t.Errorf("got paths %v, want %v", paths, want)
+	}
+}
+
+func TestGetPathsDirNotExist(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	_, err := GetPaths(fs, "/missing", nil)
+	if !errors.Is(err, ErrDirNotExist) {
+		t.Errorf("got err %v, want ErrDirNotExist", err)
+	}
+}
+
+func TestGetPathsNotADirectory(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/src", []byte("not a directory"), 0644)
+
+	_, err := GetPaths(fs, "/src", nil)
+	if !errors.Is(err, ErrNotADirectory) {
+		t.Errorf("got err %v, want ErrNotADirectory", err)
+	}
+}
+
+func TestGetPathsNoFilesFound(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/src/README.md", []byte("# readme"), 0644)
+	afero.WriteFile(fs, "/src/.vercelignore", []byte("*.md\n"), 0644)
+
+	ignores, err := GetIgnores(fs, "/src")
+	if err != nil {
+		t.Fatalf("GetIgnores returned unexpected error: %s", err)
+	}
+
+	_, err = GetPaths(fs, "/src", ignores)
+	if !errors.Is(err, ErrNoFilesFound) {
+		t.Errorf("got err %v, want ErrNoFilesFound", err)
+	}
+}
+
+func TestGetIgnoresMissingFile(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/src/index.js", []byte("console.log('hi')"), 0644)
+
+	ignores, err := GetIgnores(fs, "/src")
+	if err != nil {
+		t.Fatalf("GetIgnores returned unexpected error: %s", err)
+	}
+	if len(ignores) != 0 {
+		t.Errorf("got ignores %v, want none", ignores)
+	}
+}
@@ -2,17 +2,47 @@ package vercel
 
 import (
 	"context"
-	"crypto/sha1"
-	"encoding/hex"
+	"errors"
 	"fmt"
-	"os"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/spf13/afero"
 	"github.com/vercel/terraform-provider-vercel/glob"
 )
 
+// addDirectoryError appends a diagnostic to diags describing err, picking a
+// summary appropriate to the underlying glob sentinel error so users can
+// tell a missing directory apart from one that is simply empty.
+func addDirectoryError(diags *diag.Diagnostics, path string, err error) {
+	switch {
+	case errors.Is(err, glob.ErrDirNotExist):
+		diags.AddError(
+			"Directory Not Found",
+			fmt.Sprintf("The directory %s does not exist.", path),
+		)
+	case errors.Is(err, glob.ErrNotADirectory):
+		diags.AddError(
+			"Path Is Not A Directory",
+			fmt.Sprintf("The path %s exists, but is not a directory.", path),
+		)
+	case errors.Is(err, glob.ErrNoFilesFound):
+		diags.AddError(
+			"No Files Found",
+			fmt.Sprintf("The directory %s contains no files once `.vercelignore` rules are applied. "+
+				"Set `allow_empty = true` if this is expected.", path),
+		)
+	default:
+		diags.AddError(
+			"Error Reading Directory",
+			fmt.Sprintf("Could not read files for directory %s, unexpected error: %s", path, err),
+		)
+	}
+}
+
 type dataSourceProjectDirectoryType struct{}
 
 func (r dataSourceProjectDirectoryType) GetSchema(_ context.Context) (tfsdk.Schema, diag.Diagnostics) {
@@ -43,24 +73,61 @@ This will recursively read files, providing metadata for use with a deployment.
 					ElemType: types.StringType,
 				},
 			},
+			"allow_empty": {
+				Description: "If set to `true`, an empty `path` (or one where every file has been excluded by a vercelignore) is allowed and results in an empty `files` map, rather than an error. This defaults to `false`, since an empty deployment is usually a sign that a change to the filesystem or to the vercelignore rules has accidentally excluded everything.",
+				Optional:    true,
+				Type:        types.BoolType,
+			},
+			"hash_algorithm": {
+				Description: "The algorithm used to hash each file, one of `sha1` or `sha256`. Defaults to `sha1`.",
+				Optional:    true,
+				Type:        types.StringType,
+			},
+			"concurrency": {
+				Description: "The number of files to hash in parallel. Defaults to `GOMAXPROCS`.",
+				Optional:    true,
+				Type:        types.Int64Type,
+			},
+			"max_file_size": {
+				Description: "The maximum size, in bytes, of a single file that can be included. Files larger than this are reported as an error rather than read into memory. Defaults to unlimited.",
+				Optional:    true,
+				Type:        types.Int64Type,
+			},
 		},
 	}, nil
 }
 
 func (r dataSourceProjectDirectoryType) NewDataSource(ctx context.Context, p tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
 	return dataSourceProjectDirectory{
-		p: *(p.(*provider)),
+		p:  *(p.(*provider)),
+		fs: glob.DefaultFs,
 	}, nil
 }
 
+// newDataSourceProjectDirectoryWithFs builds the data source against a
+// caller-supplied afero.Fs, bypassing NewDataSource's default of
+// glob.DefaultFs. This exists so acceptance tests can exercise Read against
+// an in-memory filesystem instead of fixture directories on disk.
+func newDataSourceProjectDirectoryWithFs(p provider, fs afero.Fs) dataSourceProjectDirectory {
+	return dataSourceProjectDirectory{
+		p:  p,
+		fs: fs,
+	}
+}
+
 type dataSourceProjectDirectory struct {
-	p provider
+	p  provider
+	fs afero.Fs
 }
 
 type ProjectDirectoryData struct {
-	Path  types.String      `tfsdk:"path"`
-	ID    types.String      `tfsdk:"id"`
-	Files map[string]string `tfsdk:"files"`
+	Path          types.String      `tfsdk:"path"`
+	ID            types.String      `tfsdk:"id"`
+	Files         map[string]string `tfsdk:"files"`
+	AllowEmpty    types.Bool        `tfsdk:"allow_empty"`
+	HashAlgorithm types.String      `tfsdk:"hash_algorithm"`
+	Concurrency   types.Int64       `tfsdk:"concurrency"`
+	MaxFileSize   types.Int64       `tfsdk:"max_file_size"`
 }
 
 func (r dataSourceProjectDirectory) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
@@ -71,48 +138,62 @@ func (r dataSourceProjectDirectory) Read(ctx context.Context, req tfsdk.ReadData
 		return
 	}
 
-	ignoreRules, err := glob.GetIgnores(config.Path.Value)
+	fs := r.fs
+	if fs == nil {
+		fs = glob.DefaultFs
+	}
+
+	ignoreRules, err := glob.GetIgnores(fs, config.Path.Value)
 	if err != nil {
+		addDirectoryError(&resp.Diagnostics, config.Path.Value, err)
+		return
+	}
+
+	paths, err := glob.GetPaths(fs, config.Path.Value, ignoreRules)
+	if err != nil {
+		if errors.Is(err, glob.ErrNoFilesFound) && config.AllowEmpty.Value {
+			paths = nil
+		} else {
+			addDirectoryError(&resp.Diagnostics, config.Path.Value, err)
+			return
+		}
+	}
+
+	if _, err := newHasher(config.HashAlgorithm.Value); err != nil {
 		resp.Diagnostics.AddError(
-			"Error reading .vercelignore file",
-			fmt.Sprintf("Could not read file, unexpected error: %s",
-				err,
-			),
+			"Invalid hash_algorithm",
+			err.Error(),
 		)
 		return
 	}
 
-	paths, err := glob.GetPaths(config.Path.Value, ignoreRules)
+	files, tooLarge, err := hashFiles(fs, paths, config.HashAlgorithm.Value, config.MaxFileSize.Value, int(config.Concurrency.Value))
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error reading directory",
-			fmt.Sprintf("Could not read files for directory %s, unexpected error: %s",
+			"Error Reading File",
+			fmt.Sprintf("Read %d of %d files in %s successfully; the first failure was: %s",
+				len(files),
+				len(paths),
 				config.Path.Value,
 				err,
 			),
 		)
 		return
 	}
-
-	config.Files = map[string]string{}
-	for _, path := range paths {
-		content, err := os.ReadFile(path)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error reading file",
-				fmt.Sprintf("Could not read file %s, unexpected error: %s",
-					config.Path.Value,
-					err,
-				),
-			)
-			return
-		}
-		rawSha := sha1.Sum(content)
-		sha := hex.EncodeToString(rawSha[:])
-
-		config.Files[path] = fmt.Sprintf("%d~%s", len(content), sha)
+	if len(tooLarge) > 0 {
+		sort.Strings(tooLarge)
+		resp.Diagnostics.AddError(
+			"File Too Large",
+			fmt.Sprintf("The following files in %s exceed the configured max_file_size of %d bytes:\n- %s",
+				config.Path.Value,
+				config.MaxFileSize.Value,
+				strings.Join(tooLarge, "\n- "),
+			),
+		)
+		return
 	}
 
+	config.Files = files
 	config.ID = config.Path
 	diags = resp.State.Set(ctx, &config)
 	resp.Diagnostics.Append(diags...)
@@ -0,0 +1,156 @@
+package vercel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/spf13/afero"
+)
+
+// readProjectDirectory drives dataSourceProjectDirectory.Read end-to-end
+// against an in-memory afero.Fs, the way newDataSourceProjectDirectoryWithFs
+// is meant to be used in acceptance tests. configAttrs supplies values for
+// whichever config attributes the test cares about; anything omitted is left
+// null, matching an unset optional attribute.
+func readProjectDirectory(t *testing.T, fs afero.Fs, configAttrs map[string]interface{}) (ProjectDirectoryData, tfsdk.ReadDataSourceResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	schema, diags := (dataSourceProjectDirectoryType{}).GetSchema(ctx)
+	if diags.HasError() {
+		t.Fatalf("GetSchema returned unexpected diagnostics: %v", diags)
+	}
+
+	objType, ok := schema.TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		t.Fatalf("schema.TerraformType returned %T, want tftypes.Object", schema.TerraformType(ctx))
+	}
+
+	attrs := make(map[string]tftypes.Value, len(objType.AttributeTypes))
+	for name, typ := range objType.AttributeTypes {
+		if v, ok := configAttrs[name]; ok {
+			attrs[name] = tftypes.NewValue(typ, v)
+		} else {
+			attrs[name] = tftypes.NewValue(typ, nil)
+		}
+	}
+
+	req := tfsdk.ReadDataSourceRequest{
+		Config: tfsdk.Config{
+			Raw:    tftypes.NewValue(objType, attrs),
+			Schema: schema,
+		},
+	}
+	resp := tfsdk.ReadDataSourceResponse{
+		State: tfsdk.State{Schema: schema},
+	}
+
+	r := newDataSourceProjectDirectoryWithFs(provider{}, fs)
+	r.Read(ctx, req, &resp)
+
+	var out ProjectDirectoryData
+	if !resp.Diagnostics.HasError() {
+		if stateDiags := resp.State.Get(ctx, &out); stateDiags.HasError() {
+			t.Fatalf("State.Get returned unexpected diagnostics: %v", stateDiags)
+		}
+	}
+
+	return out, resp
+}
+
+func TestReadProjectDirectoryDirNotExist(t *testing.T) {
+	fs := afero.NewMemMapFs()
+
+	_, resp := readProjectDirectory(t, fs, map[string]interface{}{"path": "/src"})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error, got none")
+	}
+	if got := resp.Diagnostics[0].Summary(); got != "Directory Not Found" {
+		t.Errorf("got diagnostic summary %q, want %q", got, "Directory Not Found")
+	}
+}
+
+func TestReadProjectDirectoryNotADirectory(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/src", []byte("not a directory"), 0644)
+
+	_, resp := readProjectDirectory(t, fs, map[string]interface{}{"path": "/src"})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error, got none")
+	}
+	if got := resp.Diagnostics[0].Summary(); got != "Path Is Not A Directory" {
+		t.Errorf("got diagnostic summary %q, want %q", got, "Path Is Not A Directory")
+	}
+}
+
+func TestReadProjectDirectoryEmptyWithoutAllowEmpty(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/src/README.md", []byte("# readme"), 0644)
+	afero.WriteFile(fs, "/src/.vercelignore", []byte("*.md\n"), 0644)
+
+	_, resp := readProjectDirectory(t, fs, map[string]interface{}{"path": "/src"})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error, got none")
+	}
+	if got := resp.Diagnostics[0].Summary(); got != "No Files Found" {
+		t.Errorf("got diagnostic summary %q, want %q", got, "No Files Found")
+	}
+}
+
+func TestReadProjectDirectoryEmptyWithAllowEmpty(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/src/README.md", []byte("# readme"), 0644)
+	afero.WriteFile(fs, "/src/.vercelignore", []byte("*.md\n"), 0644)
+
+	data, resp := readProjectDirectory(t, fs, map[string]interface{}{
+		"path":        "/src",
+		"allow_empty": true,
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("got unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if len(data.Files) != 0 {
+		t.Errorf("got files %v, want an empty map", data.Files)
+	}
+}
+
+func TestReadProjectDirectoryInvalidHashAlgorithm(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/src/index.js", []byte("console.log('hi')"), 0644)
+
+	_, resp := readProjectDirectory(t, fs, map[string]interface{}{
+		"path":           "/src",
+		"hash_algorithm": "md5",
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error, got none")
+	}
+	if got := resp.Diagnostics[0].Summary(); got != "Invalid hash_algorithm" {
+		t.Errorf("got diagnostic summary %q, want %q", got, "Invalid hash_algorithm")
+	}
+}
+
+func TestReadProjectDirectoryFileTooLarge(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/src/small.js", []byte("ok"), 0644)
+	afero.WriteFile(fs, "/src/big.js", []byte("this file is too big"), 0644)
+
+	_, resp := readProjectDirectory(t, fs, map[string]interface{}{
+		"path":          "/src",
+		"max_file_size": int64(2),
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error, got none")
+	}
+	if got := resp.Diagnostics[0].Summary(); got != "File Too Large" {
+		t.Errorf("got diagnostic summary %q, want %q", got, "File Too Large")
+	}
+}
@@ -0,0 +1,154 @@
+package vercel
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+// fileHashResult is the outcome of hashing a single file, produced by a
+// hashFiles worker and consumed back on the caller's goroutine.
+type fileHashResult struct {
+	path     string
+	value    string
+	tooLarge bool
+	err      error
+}
+
+// newHasher returns a fresh hash.Hash for the given algorithm name. An empty
+// algorithm defaults to sha1, matching the digest this data source has
+// always produced.
+func newHasher(algorithm string) (hash.Hash, error) {
+	switch algorithm {
+	case "", "sha1":
+		return sha1.New(), nil
+	case "sha256":
+		return sha256.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported hash_algorithm %q: must be one of sha1, sha256", algorithm)
+	}
+}
+
+// hashFile streams path through a hasher for algorithm, never buffering more
+// than a small amount in memory at once. If maxFileSize is greater than
+// zero and path contains more bytes than that, tooLarge is set on the
+// result rather than returning an error, so the caller can report every
+// offending path in a single diagnostic.
+func hashFile(fs afero.Fs, path string, algorithm string, maxFileSize int64) fileHashResult {
+	f, err := fs.Open(path)
+	if err != nil {
+		return fileHashResult{path: path, err: err}
+	}
+	defer f.Close()
+
+	h, err := newHasher(algorithm)
+	if err != nil {
+		return fileHashResult{path: path, err: err}
+	}
+
+	if maxFileSize > 0 {
+		n, err := io.CopyN(h, f, maxFileSize)
+		if err != nil && err != io.EOF {
+			return fileHashResult{path: path, err: err}
+		}
+		if err == nil {
+			// io.CopyN stopped because it hit maxFileSize, not because the
+			// file ended - peek a byte to tell the two cases apart without
+			// risking a maxFileSize+1 overflow when maxFileSize is near
+			// math.MaxInt64.
+			var extra [1]byte
+			if read, _ := f.Read(extra[:]); read > 0 {
+				return fileHashResult{path: path, tooLarge: true}
+			}
+		}
+		value := fmt.Sprintf("v2:%s:%d~%s", algorithmName(algorithm), n, hex.EncodeToString(h.Sum(nil)))
+		return fileHashResult{path: path, value: value}
+	}
+
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return fileHashResult{path: path, err: err}
+	}
+
+	value := fmt.Sprintf("v2:%s:%d~%s", algorithmName(algorithm), n, hex.EncodeToString(h.Sum(nil)))
+	return fileHashResult{path: path, value: value}
+}
+
+// algorithmName normalises the empty (default) algorithm to "sha1" so it is
+// always spelled out explicitly in the file map value.
+func algorithmName(algorithm string) string {
+	if algorithm == "" {
+		return "sha1"
+	}
+	return algorithm
+}
+
+// hashFiles hashes every path concurrently across a pool of concurrency
+// workers, streaming each file through the hasher rather than reading it
+// fully into memory. It returns the computed file map, the subset of paths
+// that exceeded maxFileSize (if any), and the first read/hash error
+// encountered, if any. The returned map is populated with every file that
+// was successfully hashed even when an error is also returned, so callers
+// can report how much progress was made before the failure.
+func hashFiles(fs afero.Fs, paths []string, algorithm string, maxFileSize int64, concurrency int) (map[string]string, []string, error) {
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+	if concurrency > len(paths) {
+		concurrency = len(paths)
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	jobs := make(chan string)
+	results := make(chan fileHashResult)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range jobs {
+				results <- hashFile(fs, path, algorithm, maxFileSize)
+			}
+		}()
+	}
+
+	go func() {
+		for _, path := range paths {
+			jobs <- path
+		}
+		close(jobs)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	files := map[string]string{}
+	var tooLarge []string
+	var firstErr error
+	for res := range results {
+		switch {
+		case res.tooLarge:
+			tooLarge = append(tooLarge, res.path)
+		case res.err != nil && firstErr == nil:
+			firstErr = fmt.Errorf("%s: %w", res.path, res.err)
+		default:
+			if res.err == nil {
+				files[res.path] = res.value
+			}
+		}
+	}
+
+	return files, tooLarge, firstErr
+}
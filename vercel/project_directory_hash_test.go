@@ -0,0 +1,70 @@
+package vercel
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+func TestHashFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/src/a.js", []byte("a"), 0644)
+	afero.WriteFile(fs, "/src/b.js", []byte("bb"), 0644)
+
+	files, tooLarge, err := hashFiles(fs, []string{"/src/a.js", "/src/b.js"}, "sha256", 0, 2)
+	if err != nil {
+		t.Fatalf("hashFiles returned unexpected error: %s", err)
+	}
+	if len(tooLarge) != 0 {
+		t.Fatalf("got tooLarge %v, want none", tooLarge)
+	}
+	if len(files) != 2 {
+		t.Fatalf("got %d files, want 2", len(files))
+	}
+	for path, value := range files {
+		if !strings.HasPrefix(value, "v2:sha256:") {
+			t.Errorf("file %s has value %q, want v2:sha256: prefix", path, value)
+		}
+	}
+}
+
+func TestHashFilesMaxFileSize(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/src/a.js", []byte("small"), 0644)
+	afero.WriteFile(fs, "/src/b.js", []byte("this one is too big"), 0644)
+
+	files, tooLarge, err := hashFiles(fs, []string{"/src/a.js", "/src/b.js"}, "sha1", 5, 2)
+	if err != nil {
+		t.Fatalf("hashFiles returned unexpected error: %s", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("got %d files, want 1", len(files))
+	}
+	if len(tooLarge) != 1 || tooLarge[0] != "/src/b.js" {
+		t.Errorf("got tooLarge %v, want [/src/b.js]", tooLarge)
+	}
+}
+
+func TestHashFilesMaxFileSizeNearMaxInt64(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	afero.WriteFile(fs, "/src/a.js", []byte("small"), 0644)
+
+	files, tooLarge, err := hashFiles(fs, []string{"/src/a.js"}, "sha1", math.MaxInt64, 1)
+	if err != nil {
+		t.Fatalf("hashFiles returned unexpected error: %s", err)
+	}
+	if len(tooLarge) != 0 {
+		t.Fatalf("got tooLarge %v, want none", tooLarge)
+	}
+	if files["/src/a.js"] == "" {
+		t.Fatal("expected a.js to be hashed, got no entry")
+	}
+}
+
+func TestNewHasherUnsupported(t *testing.T) {
+	if _, err := newHasher("md5"); err == nil {
+		t.Error("expected an error for an unsupported algorithm, got nil")
+	}
+}